@@ -19,8 +19,18 @@ import (
 	"github.com/docker/libcompose/project/options"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/util/intstr"
 	"github.com/kubernetes/pkg/api/latest"
 	"github.com/kubernetes/pkg/client"
+
+	"github.com/JJediny/kompose/pkg/kobject"
+	kuberoptions "github.com/JJediny/kompose/pkg/options"
+	"github.com/JJediny/kompose/pkg/transformer"
+	helmtransform "github.com/JJediny/kompose/pkg/transformer/helm"
+	rawkube "github.com/JJediny/kompose/pkg/transformer/kubernetes"
+	"github.com/JJediny/kompose/pkg/transformer/kustomize"
+	"github.com/JJediny/kompose/pkg/transformer/openshift"
 )
 
 // ProjectAction is an adapter to allow the use of ordinary functions as libcompose actions.
@@ -64,60 +74,46 @@ func ProjectPs(p project.APIProject, c *cli.Context) error {
 	return nil
 }
 
-func ProjectKuberConfig(p *project.Project, c *cli.Context) {
-	url := c.String("host")
-	confDir := "~"
-	outputFileName := fmt.Sprintf(".kuberconfig")
-	outputFilePath := filepath.Join(confDir, outputFileName)
-	if err := ioutil.WriteFile(outputFilePath, url, 0644); err != nil {
-		logrus.Fatalf("Failed to write k8s api server address to %s: %v", outputFilePath, err)
-	}
-	fmt.Println(outputFilePath)
-}
-
+// ProjectKuber converts a compose project to Kubernetes objects and hands
+// them to the --provider Transformer (kubernetes, openshift, helm or
+// kustomize; kubernetes is the default, and --chart is a shorthand for
+// helm). Only the kubernetes provider also applies the objects to a live
+// API server; the others just render files into outputDir.
 func ProjectKuber(p *project.Project, c *cli.Context) {
-	outputDir := c.String("output")
-	composeFile := c.String("file")
+	opt := kuberOptionsFrom(c)
 
 	p = project.NewProject(&project.Context{
 		ProjectName: "kube",
-		ComposeFile: composeFile,
+		ComposeFile: opt.ComposeFile,
 	})
 
 	if err := p.Parse(); err != nil {
-		logrus.Fatalf("Failed to parse the compose project from %s: %v", composeFile, err)
+		logrus.Fatalf("Failed to parse the compose project from %s: %v", opt.ComposeFile, err)
 	}
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		logrus.Fatalf("Failed to create the output directory %s: %v", outputDir, err)
+	if err := os.MkdirAll(opt.OutputDir, 0755); err != nil {
+		logrus.Fatalf("Failed to create the output directory %s: %v", opt.OutputDir, err)
 	}
 
-	//Get config client
-	outputFilePath := filepath.Join("~", ".kuberconfig")
-	if server, err := ioutil.ReadFile(outputFilePath); err != nil {
-		logrus.Fatalf("Failed to read k8s api server address from %s: %v", outputFilePath, err)
-	}
-	if server == "" {
-		logrus.Fatalf("K8s api server address isn't defined in %s", outputFilePath)
-	}
+	objects := &kobject.KubernetesObjects{}
 
-	version := os.Getenv("KUBE_API_VERSION")
-	if version == "" {
-		version = latest.Version
+	pvcs := projectVolumeClaims(p, opt.StorageClassName)
+	for _, pvc := range pvcs {
+		objects.PVCs = append(objects.PVCs, pvc)
 	}
-	// create new client
-	client := client.NewOrDie(&client.Config{Host: server, Version: version})
 
 	for name, service := range p.Configs {
-		rc := &api.ReplicationController{
+		volumes, mounts := serviceVolumes(name, service.Volumes, pvcs)
+
+		dc := &api.Deployment{
 			TypeMeta: api.TypeMeta{
-				Kind:       "ReplicationController",
-				APIVersion: "v1",
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
 			},
 			ObjectMeta: api.ObjectMeta{
 				Name:   name,
 				Labels: map[string]string{"service": name},
 			},
-			Spec: api.ReplicationControllerSpec{
+			Spec: api.DeploymentSpec{
 				Replicas: 1,
 				Selector: map[string]string{"service": name},
 				Template: &api.PodTemplateSpec{
@@ -127,10 +123,13 @@ func ProjectKuber(p *project.Project, c *cli.Context) {
 					Spec: api.PodSpec{
 						Containers: []api.Container{
 							{
-								Name:  name,
-								Image: service.Image,
+								Name:         name,
+								Image:        service.Image,
+								VolumeMounts: mounts,
+								Resources:    containerResources(service.CPUShares, int64(service.MemLimit)),
 							},
 						},
+						Volumes: volumes,
 					},
 				},
 			},
@@ -152,57 +151,164 @@ func ProjectKuber(p *project.Project, c *cli.Context) {
 		// Configure the container ports.
 		var ports []api.ContainerPort
 		for _, port := range service.Ports {
-			portNumber, err := strconv.Atoi(port)
+			_, containerPort, _, err := parsePort(port)
 			if err != nil {
-				logrus.Fatalf("Invalid container port %s for service %s", port, name)
+				logrus.Fatalf("Invalid port %s for service %s: %v", port, name, err)
 			}
-			ports = append(ports, api.ContainerPort{ContainerPort: portNumber})
+			ports = append(ports, api.ContainerPort{ContainerPort: containerPort})
 		}
 
-		rc.Spec.Template.Spec.Containers[0].Ports = ports
+		dc.Spec.Template.Spec.Containers[0].Ports = ports
 
-		// Configure the service ports.
-		var servicePorts []api.ServicePort
+		// Configure the service ports. When no exposeLabel is present, a
+		// "HOST:CONTAINER" port below privilegedPortCeiling also turns sc
+		// into a NodePort Service; an exposed service gets an Ingress
+		// instead (see ingressFor below), not both.
+		_, exposed := service.Labels[exposeLabel]
 		for _, port := range service.Ports {
-			portNumber, err := strconv.Atoi(port)
+			hostPort, containerPort, hasHostPort, err := parsePort(port)
 			if err != nil {
-				logrus.Fatalf("Invalid container port %s for service %s", port, name)
+				logrus.Fatalf("Invalid port %s for service %s: %v", port, name, err)
+			}
+			sc.Spec.Ports = append(sc.Spec.Ports, api.ServicePort{Port: containerPort, TargetPort: intstr.FromInt(containerPort)})
+			if !exposed {
+				applyNodePort(sc, hostPort, hasHostPort)
 			}
-			servicePorts = append(servicePorts, api.ServicePort{Port: portNumber})
 		}
-		sc.Spec.Ports = servicePorts
 
 		// Configure the container restart policy.
 		switch service.Restart {
 		case "", "always":
-			rc.Spec.Template.Spec.RestartPolicy = api.RestartPolicyAlways
+			dc.Spec.Template.Spec.RestartPolicy = api.RestartPolicyAlways
 		case "no":
-			rc.Spec.Template.Spec.RestartPolicy = api.RestartPolicyNever
+			dc.Spec.Template.Spec.RestartPolicy = api.RestartPolicyNever
 		case "on-failure":
-			rc.Spec.Template.Spec.RestartPolicy = api.RestartPolicyOnFailure
+			dc.Spec.Template.Spec.RestartPolicy = api.RestartPolicyOnFailure
 		default:
 			logrus.Fatalf("Unknown restart policy %s for service %s", service.Restart, name)
 		}
 
-		data, err := json.MarshalIndent(rc, "", "  ")
-		if err != nil {
-			logrus.Fatalf("Failed to marshal the replication controller: %v", err)
+		objects.Deployments = append(objects.Deployments, dc)
+		objects.Services = append(objects.Services, sc)
+		if ingress := ingressFor(name, sc, service.Labels); ingress != nil {
+			objects.Ingresses = append(objects.Ingresses, ingress)
 		}
+	}
 
-		// call create RC api
-		_, err := client.ReplicationControllers(api.NamespaceDefault).Create(rc)
-		if err != nil {
-			fmt.Println(err)
+	if opt.Provider == "kubernetes" {
+		applyToCluster(objects, c)
+	}
+
+	emitter, err := transformerFor(opt.Provider, p.Name)
+	if err != nil {
+		logrus.Fatalf("%v", err)
+	}
+	files, err := emitter.Transform(objects.All())
+	if err != nil {
+		logrus.Fatalf("Failed to render %s output: %v", opt.Provider, err)
+	}
+	for _, f := range files {
+		path := filepath.Join(opt.OutputDir, f.Name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			logrus.Fatalf("Failed to create directory for %s: %v", path, err)
+		}
+		if err := ioutil.WriteFile(path, f.Data, 0644); err != nil {
+			logrus.Fatalf("Failed to write %s: %v", path, err)
 		}
+	}
+}
 
-		// call create SVC api
-		_, err := client.Services(api.NamespaceDefault).Create(sc)
-		if err != nil {
+// kuberOptionsFrom reads the "kube" action's flags off c into an
+// options.Kuber, the same way ProjectUp builds an options.Up.
+func kuberOptionsFrom(c *cli.Context) kuberoptions.Kuber {
+	provider := c.String("provider")
+	if provider == "" && c.Bool("chart") {
+		provider = "helm"
+	}
+	if provider == "" {
+		provider = "kubernetes"
+	}
+
+	return kuberoptions.Kuber{
+		ComposeFile:      c.String("file"),
+		OutputDir:        c.String("output"),
+		Provider:         provider,
+		Chart:            c.Bool("chart"),
+		StorageClassName: c.String("storage-class"),
+	}
+}
+
+// transformerFor resolves the --provider flag to a Transformer.
+// chartName is used only by the helm provider, to name the generated chart.
+func transformerFor(provider, chartName string) (transformer.Transformer, error) {
+	switch provider {
+	case "kubernetes":
+		return rawkube.New(), nil
+	case "openshift":
+		return openshift.New(), nil
+	case "helm":
+		return helmtransform.New(chartName), nil
+	case "kustomize":
+		return kustomize.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q", provider)
+	}
+}
+
+// applyToCluster POSTs a KubernetesObjects' Deployments, Services and
+// PersistentVolumeClaims to the configured live API server, in addition to
+// whatever the chosen Transformer renders to disk. Ingresses are not
+// applied; they are only rendered to disk.
+func applyToCluster(objects *kobject.KubernetesObjects, c *cli.Context) {
+	clientConfig, namespace, err := resolveClientConfig(c)
+	if err != nil {
+		logrus.Fatalf("%v", err)
+	}
+	if namespace == "" {
+		namespace = api.NamespaceDefault
+	}
+
+	clientConfig.Version = os.Getenv("KUBE_API_VERSION")
+	if clientConfig.Version == "" {
+		clientConfig.Version = latest.Version
+	}
+
+	kubeClient, err := client.New(clientConfig)
+	if err != nil {
+		logrus.Fatalf("Failed to create a Kubernetes client: %v", err)
+	}
+
+	for _, dc := range objects.Deployments {
+		if _, err := kubeClient.Deployments(namespace).Create(dc); err != nil {
+			fmt.Println(err)
+		}
+	}
+	for _, sc := range objects.Services {
+		if _, err := kubeClient.Services(namespace).Create(sc); err != nil {
+			fmt.Println(err)
+		}
+	}
+	for _, pvc := range objects.PVCs {
+		if _, err := kubeClient.PersistentVolumeClaims(namespace).Create(pvc); err != nil {
 			fmt.Println(err)
 		}
 	}
 }
 
+// containerResources maps a compose service's cpu_shares/mem_limit onto the
+// equivalent Kubernetes ResourceRequirements.
+func containerResources(cpuShares, memLimit int64) api.ResourceRequirements {
+	limits := api.ResourceList{}
+	if cpuShares != 0 {
+		// 1024 CPU shares are one CPU, i.e. 1000 millicores.
+		limits[api.ResourceCPU] = *resource.NewMilliQuantity(cpuShares*1000/1024, resource.DecimalSI)
+	}
+	if memLimit != 0 {
+		limits[api.ResourceMemory] = *resource.NewQuantity(memLimit, resource.BinarySI)
+	}
+	return api.ResourceRequirements{Limits: limits}
+}
+
 func ProjectPort(p *project.Project, c *cli.Context) {
 	if len(c.Args()) != 2 {
 		return cli.NewExitError("Please pass arguments in the form: SERVICE PORT", 1)
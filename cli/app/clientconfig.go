@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/codegangsta/cli"
+
+	"k8s.io/kubernetes/pkg/client/clientcmd"
+
+	"github.com/kubernetes/pkg/client"
+
+	"github.com/JJediny/kompose/pkg/config"
+)
+
+// resolveClientConfig builds the client.Config ProjectKuber/ProjectKuberReverse
+// should talk to, honoring --context or $KOMPOSE_CONTEXT, then the kompose
+// config's current context, and only then falling back to merging
+// $KUBECONFIG via clientcmd. It returns a clear error instead of the
+// client.NewOrDie panic the old code relied on.
+func resolveClientConfig(c *cli.Context) (*client.Config, string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, "", err
+	}
+
+	contextName := c.String("context")
+	if contextName == "" {
+		contextName = os.Getenv("KOMPOSE_CONTEXT")
+	}
+	if contextName == "" {
+		contextName = cfg.Current
+	}
+
+	if contextName != "" {
+		ctx, ok := cfg.Get(contextName)
+		if !ok {
+			return nil, "", fmt.Errorf("no such kompose context %q; run \"kompose config list\" to see what's available", contextName)
+		}
+		return &client.Config{
+			Host:        ctx.Server,
+			CAFile:      ctx.CAFile,
+			BearerToken: ctx.Token,
+			Insecure:    ctx.Insecure,
+		}, ctx.Namespace, nil
+	}
+
+	// No kompose context selected: fall back to merging $KUBECONFIG.
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
+		loadingRules.ExplicitPath = kubeconfig
+	}
+	rawConfig, err := loadingRules.Load()
+	if err != nil {
+		return nil, "", fmt.Errorf("no kompose context is set (use \"kompose config set\"/\"kompose config use\", or --context) and $KUBECONFIG could not be loaded: %v", err)
+	}
+
+	overridden := clientcmd.NewDefaultClientConfig(*rawConfig, &clientcmd.ConfigOverrides{})
+	restConfig, err := overridden.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("no kompose context is set and $KUBECONFIG could not be used: %v", err)
+	}
+	namespace, _, err := overridden.Namespace()
+	if err != nil {
+		namespace = ""
+	}
+
+	return &client.Config{
+		Host:        restConfig.Host,
+		CAFile:      restConfig.CAFile,
+		BearerToken: restConfig.BearerToken,
+		Insecure:    restConfig.Insecure,
+	}, namespace, nil
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/codegangsta/cli"
+)
+
+// TestResolveClientConfigUnknownContext exercises the first branch of the
+// fallback chain: an explicit --context that isn't in the kompose config
+// must fail with a clear error instead of falling through to $KUBECONFIG.
+func TestResolveClientConfigUnknownContext(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String("context", "no-such-context", "")
+	c := cli.NewContext(nil, set, nil)
+
+	_, _, err := resolveClientConfig(c)
+	if err == nil {
+		t.Fatal("resolveClientConfig with an unknown --context = nil error, want one naming the context")
+	}
+}
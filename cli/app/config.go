@@ -0,0 +1,103 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/codegangsta/cli"
+
+	"github.com/JJediny/kompose/pkg/config"
+)
+
+// ConfigSet adds or overwrites a named kompose context: "kompose config set
+// <name> --server ... [--ca-file ...] [--token ...] [--namespace ...]
+// [--insecure]".
+func ConfigSet(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return cli.NewExitError("Please specify a context name", 1)
+	}
+	server := c.String("server")
+	if server == "" {
+		return cli.NewExitError("Please specify --server", 1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	cfg.Set(config.Context{
+		Name:      name,
+		Server:    server,
+		CAFile:    c.String("ca-file"),
+		Token:     c.String("token"),
+		Namespace: c.String("namespace"),
+		Insecure:  c.Bool("insecure"),
+	})
+
+	if err := config.Save(cfg); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	fmt.Printf("Context %q set\n", name)
+	return nil
+}
+
+// ConfigUse makes the named context the default ProjectKuber uses when
+// --context and $KOMPOSE_CONTEXT are both unset.
+func ConfigUse(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return cli.NewExitError("Please specify a context name", 1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	if err := cfg.Use(name); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	if err := config.Save(cfg); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	fmt.Printf("Switched to context %q\n", name)
+	return nil
+}
+
+// ConfigList prints every known context, marking the current one.
+func ConfigList(c *cli.Context) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	for _, ctx := range cfg.Contexts {
+		marker := " "
+		if ctx.Name == cfg.Current {
+			marker = "*"
+		}
+		fmt.Printf("%s %s\t%s\n", marker, ctx.Name, ctx.Server)
+	}
+	return nil
+}
+
+// ConfigDelete removes a named context.
+func ConfigDelete(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return cli.NewExitError("Please specify a context name", 1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	if !cfg.Delete(name) {
+		return cli.NewExitError(fmt.Sprintf("No such context %q", name), 1)
+	}
+	if err := config.Save(cfg); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	fmt.Printf("Context %q deleted\n", name)
+	return nil
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api"
+
+	"github.com/JJediny/kompose/pkg/kobject"
+)
+
+const (
+	// exposeLabel names the host an Ingress should be created for.
+	exposeLabel = "kompose.service.expose"
+	// exposeTLSSecretLabel names the Secret an Ingress's TLS section
+	// should reference, if set alongside exposeLabel.
+	exposeTLSSecretLabel = "kompose.service.expose.tls-secret"
+	// privilegedPortCeiling bounds NodePort handling for an unlabeled
+	// "HOST:CONTAINER" port mapping: only host ports below it trigger it.
+	privilegedPortCeiling = 1024
+)
+
+// parsePort parses a compose "ports:" entry, which is either a bare
+// container port ("80") or "HOST:CONTAINER" ("8080:80"). hasHostPort is
+// false for the bare form.
+func parsePort(port string) (hostPort, containerPort int, hasHostPort bool, err error) {
+	parts := strings.Split(port, ":")
+	switch len(parts) {
+	case 1:
+		containerPort, err = strconv.Atoi(parts[0])
+		return 0, containerPort, false, err
+	case 2:
+		if hostPort, err = strconv.Atoi(parts[0]); err != nil {
+			return 0, 0, false, err
+		}
+		containerPort, err = strconv.Atoi(parts[1])
+		return hostPort, containerPort, true, err
+	default:
+		return 0, 0, false, fmt.Errorf("invalid port %q", port)
+	}
+}
+
+// applyNodePort switches sc to a NodePort Service when a published host
+// port below privilegedPortCeiling was requested, recording that port as
+// the NodePort of sc's last port (the one the caller just added for it).
+func applyNodePort(sc *api.Service, hostPort int, hasHostPort bool) {
+	if !hasHostPort || hostPort == 0 || hostPort >= privilegedPortCeiling {
+		return
+	}
+	sc.Spec.Type = api.ServiceTypeNodePort
+	if n := len(sc.Spec.Ports); n > 0 {
+		sc.Spec.Ports[n-1].NodePort = hostPort
+	}
+}
+
+// ingressFor builds an Ingress pointed at sc's first port when labels
+// carries exposeLabel. It returns nil when the service isn't exposed this
+// way, so callers can skip it.
+func ingressFor(name string, sc *api.Service, labels map[string]string) *kobject.Ingress {
+	host, ok := labels[exposeLabel]
+	if !ok || host == "" || len(sc.Spec.Ports) == 0 {
+		return nil
+	}
+
+	ingress := &kobject.Ingress{
+		TypeMeta: api.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: api.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"service": name},
+		},
+		Spec: kobject.IngressSpec{
+			Rules: []kobject.IngressRule{
+				{
+					Host: host,
+					HTTP: &kobject.HTTPIngressRuleValue{
+						Paths: []kobject.HTTPIngressPath{
+							{
+								Path: "/",
+								Backend: kobject.IngressBackend{
+									Service: kobject.IngressServiceBackend{
+										Name: name,
+										Port: kobject.IngressServicePort{Number: sc.Spec.Ports[0].Port},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if secret := labels[exposeTLSSecretLabel]; secret != "" {
+		ingress.Spec.TLS = []kobject.IngressTLS{
+			{Hosts: []string{host}, SecretName: secret},
+		}
+	}
+
+	return ingress
+}
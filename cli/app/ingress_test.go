@@ -0,0 +1,109 @@
+package app
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		name          string
+		port          string
+		wantHost      int
+		wantContainer int
+		wantHasHost   bool
+		wantErr       bool
+	}{
+		{name: "bare container port", port: "80", wantHost: 0, wantContainer: 80, wantHasHost: false},
+		{name: "host:container", port: "8080:80", wantHost: 8080, wantContainer: 80, wantHasHost: true},
+		{name: "invalid bare port", port: "nope", wantErr: true},
+		{name: "invalid host", port: "nope:80", wantErr: true},
+		{name: "invalid container", port: "8080:nope", wantErr: true},
+		{name: "too many parts", port: "1:2:3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostPort, containerPort, hasHostPort, err := parsePort(tt.port)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsePort(%q): expected an error, got none", tt.port)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePort(%q): unexpected error: %v", tt.port, err)
+			}
+			if hostPort != tt.wantHost || containerPort != tt.wantContainer || hasHostPort != tt.wantHasHost {
+				t.Errorf("parsePort(%q) = (%d, %d, %v), want (%d, %d, %v)",
+					tt.port, hostPort, containerPort, hasHostPort, tt.wantHost, tt.wantContainer, tt.wantHasHost)
+			}
+		})
+	}
+}
+
+func TestApplyNodePort(t *testing.T) {
+	tests := []struct {
+		name         string
+		hostPort     int
+		hasHostPort  bool
+		wantType     api.ServiceType
+		wantNodePort int
+	}{
+		{name: "no host port", hostPort: 0, hasHostPort: false, wantType: "", wantNodePort: 0},
+		{name: "privileged host port becomes NodePort", hostPort: 80, hasHostPort: true, wantType: api.ServiceTypeNodePort, wantNodePort: 80},
+		{name: "host port at the ceiling is left alone", hostPort: privilegedPortCeiling, hasHostPort: true, wantType: "", wantNodePort: 0},
+		{name: "unprivileged host port is left alone", hostPort: 8080, hasHostPort: true, wantType: "", wantNodePort: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := &api.Service{Spec: api.ServiceSpec{Ports: []api.ServicePort{{Port: 80}}}}
+			applyNodePort(sc, tt.hostPort, tt.hasHostPort)
+			if sc.Spec.Type != tt.wantType {
+				t.Errorf("Spec.Type = %q, want %q", sc.Spec.Type, tt.wantType)
+			}
+			if sc.Spec.Ports[0].NodePort != tt.wantNodePort {
+				t.Errorf("Ports[0].NodePort = %d, want %d", sc.Spec.Ports[0].NodePort, tt.wantNodePort)
+			}
+		})
+	}
+}
+
+func TestIngressFor(t *testing.T) {
+	sc := &api.Service{Spec: api.ServiceSpec{Ports: []api.ServicePort{{Port: 80}}}}
+
+	if got := ingressFor("web", sc, nil); got != nil {
+		t.Fatalf("ingressFor with no labels = %+v, want nil", got)
+	}
+	if got := ingressFor("web", sc, map[string]string{exposeLabel: ""}); got != nil {
+		t.Fatalf("ingressFor with empty expose host = %+v, want nil", got)
+	}
+	if got := ingressFor("web", &api.Service{}, map[string]string{exposeLabel: "web.example.com"}); got != nil {
+		t.Fatalf("ingressFor with no service ports = %+v, want nil", got)
+	}
+
+	ingress := ingressFor("web", sc, map[string]string{exposeLabel: "web.example.com"})
+	if ingress == nil {
+		t.Fatal("ingressFor with expose label = nil, want an Ingress")
+	}
+	if ingress.TypeMeta.APIVersion != "networking.k8s.io/v1" {
+		t.Errorf("APIVersion = %q, want networking.k8s.io/v1", ingress.TypeMeta.APIVersion)
+	}
+	backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend
+	if backend.Service.Name != "web" || backend.Service.Port.Number != 80 {
+		t.Errorf("Backend = %+v, want Service{web 80}", backend)
+	}
+	if ingress.Spec.TLS != nil {
+		t.Errorf("Spec.TLS = %+v, want nil without exposeTLSSecretLabel", ingress.Spec.TLS)
+	}
+
+	withTLS := ingressFor("web", sc, map[string]string{
+		exposeLabel:          "web.example.com",
+		exposeTLSSecretLabel: "web-tls",
+	})
+	if len(withTLS.Spec.TLS) != 1 || withTLS.Spec.TLS[0].SecretName != "web-tls" {
+		t.Errorf("Spec.TLS = %+v, want one entry naming web-tls", withTLS.Spec.TLS)
+	}
+}
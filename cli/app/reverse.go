@@ -0,0 +1,303 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/codegangsta/cli"
+	"github.com/docker/libcompose/project"
+	"github.com/ghodss/yaml"
+	yamlv2 "gopkg.in/yaml.v2"
+
+	"k8s.io/kubernetes/pkg/api"
+	"github.com/kubernetes/pkg/api/latest"
+	"github.com/kubernetes/pkg/client"
+
+	"github.com/JJediny/kompose/pkg/kobject"
+)
+
+type composeFile struct {
+	Version  string                   `yaml:"version"`
+	Services map[string]composeConfig `yaml:"services"`
+	Volumes  map[string]composeVolume `yaml:"volumes,omitempty"`
+}
+
+type composeConfig struct {
+	Image       string            `yaml:"image"`
+	Command     []string          `yaml:"command,omitempty"`
+	Environment map[string]string `yaml:"environment,omitempty"`
+	Ports       []string          `yaml:"ports,omitempty"`
+	Volumes     []string          `yaml:"volumes,omitempty"`
+	Restart     string            `yaml:"restart,omitempty"`
+}
+
+type composeVolume struct {
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+}
+
+// ProjectKuberReverse rebuilds a docker-compose.yml from either a running
+// namespace or a directory of Kubernetes YAMLs, the inverse of ProjectKuber.
+// With --namespace it lists Deployments/Services/PersistentVolumeClaims from
+// the cluster resolveClientConfig selects; with --from-dir it reads the same
+// object kinds out of a directory of manifests instead.
+func ProjectKuberReverse(p *project.Project, c *cli.Context) {
+	outputFile := c.String("output")
+	if outputFile == "" {
+		outputFile = "docker-compose.yml"
+	}
+
+	objects, err := loadKubernetesObjects(c)
+	if err != nil {
+		logrus.Fatalf("Failed to load Kubernetes objects: %v", err)
+	}
+
+	compose := composeFileFromObjects(objects)
+	// gopkg.in/yaml.v2 honors composeFile's yaml tags; ghodss/yaml would
+	// marshal through encoding/json and ignore them.
+	data, err := yamlv2.Marshal(compose)
+	if err != nil {
+		logrus.Fatalf("Failed to marshal %s: %v", outputFile, err)
+	}
+	if err := ioutil.WriteFile(outputFile, data, 0644); err != nil {
+		logrus.Fatalf("Failed to write %s: %v", outputFile, err)
+	}
+	fmt.Println(outputFile)
+}
+
+// loadKubernetesObjects loads Deployments/Services/PVCs either from a
+// directory of YAMLs (--from-dir) or from the live cluster resolveClientConfig
+// selects (--namespace).
+func loadKubernetesObjects(c *cli.Context) (*kobject.KubernetesObjects, error) {
+	if dir := c.String("from-dir"); dir != "" {
+		return loadObjectsFromDir(dir)
+	}
+	return loadObjectsFromCluster(c, c.String("namespace"))
+}
+
+func loadObjectsFromDir(dir string) (*kobject.KubernetesObjects, error) {
+	objects := &kobject.KubernetesObjects{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".yaml") && !strings.HasSuffix(path, ".yml") {
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", path, err)
+		}
+
+		var meta api.TypeMeta
+		if err := yaml.Unmarshal(data, &meta); err != nil {
+			return fmt.Errorf("failed to parse kind of %s: %v", path, err)
+		}
+
+		switch meta.Kind {
+		case "Deployment":
+			dc := &api.Deployment{}
+			if err := yaml.Unmarshal(data, dc); err != nil {
+				return fmt.Errorf("failed to parse %s as a Deployment: %v", path, err)
+			}
+			objects.Deployments = append(objects.Deployments, dc)
+		case "Service":
+			sc := &api.Service{}
+			if err := yaml.Unmarshal(data, sc); err != nil {
+				return fmt.Errorf("failed to parse %s as a Service: %v", path, err)
+			}
+			objects.Services = append(objects.Services, sc)
+		case "PersistentVolumeClaim":
+			pvc := &api.PersistentVolumeClaim{}
+			if err := yaml.Unmarshal(data, pvc); err != nil {
+				return fmt.Errorf("failed to parse %s as a PersistentVolumeClaim: %v", path, err)
+			}
+			objects.PVCs = append(objects.PVCs, pvc)
+		}
+		return nil
+	})
+
+	return objects, err
+}
+
+func loadObjectsFromCluster(c *cli.Context, namespace string) (*kobject.KubernetesObjects, error) {
+	clientConfig, configNamespace, err := resolveClientConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = configNamespace
+	}
+	if namespace == "" {
+		namespace = api.NamespaceDefault
+	}
+
+	clientConfig.Version = os.Getenv("KUBE_API_VERSION")
+	if clientConfig.Version == "" {
+		clientConfig.Version = latest.Version
+	}
+	kubeClient, err := client.New(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a Kubernetes client: %v", err)
+	}
+
+	objects := &kobject.KubernetesObjects{}
+
+	deployments, err := kubeClient.Deployments(namespace).List(api.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments in %s: %v", namespace, err)
+	}
+	for i := range deployments.Items {
+		objects.Deployments = append(objects.Deployments, &deployments.Items[i])
+	}
+
+	services, err := kubeClient.Services(namespace).List(api.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services in %s: %v", namespace, err)
+	}
+	for i := range services.Items {
+		objects.Services = append(objects.Services, &services.Items[i])
+	}
+
+	pvcs, err := kubeClient.PersistentVolumeClaims(namespace).List(api.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volume claims in %s: %v", namespace, err)
+	}
+	for i := range pvcs.Items {
+		objects.PVCs = append(objects.PVCs, &pvcs.Items[i])
+	}
+
+	return objects, nil
+}
+
+// composeFileFromObjects maps a KubernetesObjects back onto a
+// docker-compose v3 file: one compose service per Deployment, its ports
+// collapsed from the matching Service, its volumes unwound from
+// VolumeMounts, and its restart policy translated back to compose syntax.
+func composeFileFromObjects(objects *kobject.KubernetesObjects) *composeFile {
+	servicesByName := map[string]*api.Service{}
+	for _, sc := range objects.Services {
+		servicesByName[sc.ObjectMeta.Name] = sc
+	}
+
+	compose := &composeFile{
+		Version:  "3",
+		Services: map[string]composeConfig{},
+		Volumes:  map[string]composeVolume{},
+	}
+
+	for _, dc := range objects.Deployments {
+		name := dc.ObjectMeta.Name
+		if len(dc.Spec.Template.Spec.Containers) == 0 {
+			logrus.Warnf("Skipping %s: Deployment has no containers", name)
+			continue
+		}
+		container := dc.Spec.Template.Spec.Containers[0]
+
+		env := map[string]string{}
+		for _, e := range container.Env {
+			env[e.Name] = e.Value
+		}
+
+		var ports []string
+		if sc, ok := servicesByName[name]; ok {
+			ports = composePorts(sc)
+		}
+
+		compose.Services[name] = composeConfig{
+			Image:       container.Image,
+			Command:     container.Command,
+			Environment: env,
+			Ports:       ports,
+			Volumes:     composeVolumeMounts(dc),
+			Restart:     composeRestart(dc.Spec.Template.Spec.RestartPolicy),
+		}
+	}
+
+	for _, pvc := range objects.PVCs {
+		v := composeVolume{}
+		if pvc.Spec.StorageClassName != nil {
+			v.DriverOpts = map[string]string{"storageClass": *pvc.Spec.StorageClassName}
+		}
+		compose.Volumes[pvc.ObjectMeta.Name] = v
+	}
+
+	return compose
+}
+
+// composePorts collapses a Service's ports into compose "HOST:CONTAINER"
+// entries. The container half comes from TargetPort; the host half comes
+// from NodePort when ProjectKuber recorded one (a published host port
+// below privilegedPortCeiling), falling back to Port otherwise.
+func composePorts(sc *api.Service) []string {
+	var ports []string
+	for _, p := range sc.Spec.Ports {
+		containerPort := p.Port
+		if p.TargetPort.IntValue() != 0 {
+			containerPort = p.TargetPort.IntValue()
+		}
+
+		hostPort := p.Port
+		if sc.Spec.Type == api.ServiceTypeNodePort && p.NodePort != 0 {
+			hostPort = p.NodePort
+		}
+
+		if hostPort != containerPort {
+			ports = append(ports, fmt.Sprintf("%d:%d", hostPort, containerPort))
+		} else {
+			ports = append(ports, fmt.Sprintf("%d", containerPort))
+		}
+	}
+	return ports
+}
+
+// composeVolumeMounts unwinds a Deployment's VolumeMounts back into compose
+// "SOURCE:TARGET" volume entries, or a bare "TARGET" for an emptyDir.
+func composeVolumeMounts(dc *api.Deployment) []string {
+	if len(dc.Spec.Template.Spec.Containers) == 0 {
+		return nil
+	}
+
+	volumesByName := map[string]api.Volume{}
+	for _, v := range dc.Spec.Template.Spec.Volumes {
+		volumesByName[v.Name] = v
+	}
+
+	var specs []string
+	for _, m := range dc.Spec.Template.Spec.Containers[0].VolumeMounts {
+		v, ok := volumesByName[m.Name]
+		if !ok {
+			continue
+		}
+		switch {
+		case v.VolumeSource.PersistentVolumeClaim != nil:
+			specs = append(specs, fmt.Sprintf("%s:%s", v.VolumeSource.PersistentVolumeClaim.ClaimName, m.MountPath))
+		case v.VolumeSource.HostPath != nil:
+			specs = append(specs, fmt.Sprintf("%s:%s", v.VolumeSource.HostPath.Path, m.MountPath))
+		case v.VolumeSource.EmptyDir != nil:
+			specs = append(specs, m.MountPath)
+		}
+	}
+	return specs
+}
+
+// composeRestart translates a Kubernetes RestartPolicy back to compose
+// restart: syntax.
+func composeRestart(policy api.RestartPolicy) string {
+	switch policy {
+	case api.RestartPolicyAlways:
+		return "always"
+	case api.RestartPolicyOnFailure:
+		return "on-failure"
+	case api.RestartPolicyNever:
+		return "no"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/intstr"
+
+	"github.com/JJediny/kompose/pkg/kobject"
+)
+
+func TestComposePorts(t *testing.T) {
+	tests := []struct {
+		name string
+		sc   *api.Service
+		want []string
+	}{
+		{
+			name: "bare container port",
+			sc: &api.Service{Spec: api.ServiceSpec{
+				Ports: []api.ServicePort{{Port: 80, TargetPort: intstr.FromInt(80)}},
+			}},
+			want: []string{"80"},
+		},
+		{
+			name: "NodePort reconstructs the published host port",
+			sc: &api.Service{Spec: api.ServiceSpec{
+				Type:  api.ServiceTypeNodePort,
+				Ports: []api.ServicePort{{Port: 80, TargetPort: intstr.FromInt(80), NodePort: 8080}},
+			}},
+			want: []string{"8080:80"},
+		},
+		{
+			name: "ClusterIP service ignores a stray NodePort and has no host half",
+			sc: &api.Service{Spec: api.ServiceSpec{
+				Ports: []api.ServicePort{{Port: 80, TargetPort: intstr.FromInt(80), NodePort: 8080}},
+			}},
+			want: []string{"80"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := composePorts(tt.sc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("composePorts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeVolumeMounts(t *testing.T) {
+	dc := &api.Deployment{Spec: api.DeploymentSpec{Template: &api.PodTemplateSpec{Spec: api.PodSpec{
+		Volumes: []api.Volume{
+			{Name: "data", VolumeSource: api.VolumeSource{PersistentVolumeClaim: &api.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+			{Name: "host", VolumeSource: api.VolumeSource{HostPath: &api.HostPathVolumeSource{Path: "/host/path"}}},
+			{Name: "tmp", VolumeSource: api.VolumeSource{EmptyDir: &api.EmptyDirVolumeSource{}}},
+		},
+		Containers: []api.Container{{
+			VolumeMounts: []api.VolumeMount{
+				{Name: "data", MountPath: "/data"},
+				{Name: "host", MountPath: "/container/host"},
+				{Name: "tmp", MountPath: "/tmp"},
+			},
+		}},
+	}}}}
+
+	want := []string{"data:/data", "/host/path:/container/host", "/tmp"}
+	if got := composeVolumeMounts(dc); !reflect.DeepEqual(got, want) {
+		t.Errorf("composeVolumeMounts() = %v, want %v", got, want)
+	}
+}
+
+func TestComposeVolumeMountsNoContainers(t *testing.T) {
+	dc := &api.Deployment{Spec: api.DeploymentSpec{Template: &api.PodTemplateSpec{}}}
+	if got := composeVolumeMounts(dc); got != nil {
+		t.Errorf("composeVolumeMounts() with no containers = %v, want nil", got)
+	}
+}
+
+// TestComposeFileFromObjectsSkipsContainerlessDeployment guards against a
+// hand-edited Deployment with an empty containers list (as --from-dir or
+// --namespace might read) panicking the whole reverse conversion.
+func TestComposeFileFromObjectsSkipsContainerlessDeployment(t *testing.T) {
+	objects := &kobject.KubernetesObjects{
+		Deployments: []*api.Deployment{
+			{ObjectMeta: api.ObjectMeta{Name: "empty"}, Spec: api.DeploymentSpec{Template: &api.PodTemplateSpec{}}},
+		},
+	}
+
+	compose := composeFileFromObjects(objects)
+	if _, ok := compose.Services["empty"]; ok {
+		t.Errorf("Services[\"empty\"] present, want the containerless Deployment skipped")
+	}
+}
+
+func TestComposeRestart(t *testing.T) {
+	tests := []struct {
+		policy api.RestartPolicy
+		want   string
+	}{
+		{api.RestartPolicyAlways, "always"},
+		{api.RestartPolicyOnFailure, "on-failure"},
+		{api.RestartPolicyNever, "no"},
+		{api.RestartPolicy("Unknown"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			if got := composeRestart(tt.policy); got != tt.want {
+				t.Errorf("composeRestart(%q) = %q, want %q", tt.policy, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/libcompose/project"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+)
+
+// projectVolumeClaims builds one PersistentVolumeClaim per named volume
+// referenced anywhere in the project, keyed by volume name, so that
+// services sharing a top-level compose volume (e.g. a shared-data or
+// sidecar pattern) share a single claim instead of each getting their own.
+func projectVolumeClaims(p *project.Project, storageClass string) map[string]*api.PersistentVolumeClaim {
+	pvcs := map[string]*api.PersistentVolumeClaim{}
+
+	for _, service := range p.Configs {
+		for _, spec := range service.Volumes {
+			source, _, ok := splitVolumeSpec(spec)
+			if !namedVolumeSource(source, ok) {
+				continue
+			}
+			if _, exists := pvcs[source]; exists {
+				continue
+			}
+
+			pvc := &api.PersistentVolumeClaim{
+				TypeMeta: api.TypeMeta{
+					Kind:       "PersistentVolumeClaim",
+					APIVersion: "v1",
+				},
+				ObjectMeta: api.ObjectMeta{
+					Name: source,
+				},
+				Spec: api.PersistentVolumeClaimSpec{
+					AccessModes: []api.PersistentVolumeAccessMode{api.ReadWriteOnce},
+					Resources: api.ResourceRequirements{
+						Requests: api.ResourceList{
+							api.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			}
+			if storageClass != "" {
+				pvc.Spec.StorageClassName = &storageClass
+			}
+			pvcs[source] = pvc
+		}
+	}
+
+	return pvcs
+}
+
+// serviceVolumes converts a compose service's volume list into the
+// pod-level Volumes and container VolumeMounts needed to reproduce it in
+// Kubernetes. It mirrors podman's kube-generate handling: named volumes
+// reference the project's shared PersistentVolumeClaims (see
+// projectVolumeClaims), absolute-path bind mounts become hostPath volumes,
+// and anonymous volumes become emptyDir.
+func serviceVolumes(name string, volumeSpecs []string, pvcs map[string]*api.PersistentVolumeClaim) ([]api.Volume, []api.VolumeMount) {
+	var volumes []api.Volume
+	var mounts []api.VolumeMount
+
+	for i, spec := range volumeSpecs {
+		source, target, ok := splitVolumeSpec(spec)
+		volumeName := fmt.Sprintf("%s-%d", name, i)
+
+		switch {
+		case !ok:
+			// Anonymous volume: "/container/path" with no host source.
+			volumes = append(volumes, api.Volume{
+				Name:         volumeName,
+				VolumeSource: api.VolumeSource{EmptyDir: &api.EmptyDirVolumeSource{}},
+			})
+		case !namedVolumeSource(source, ok):
+			// Bind mount: use the host path directly.
+			volumes = append(volumes, api.Volume{
+				Name:         volumeName,
+				VolumeSource: api.VolumeSource{HostPath: &api.HostPathVolumeSource{Path: source}},
+			})
+		default:
+			// Named volume: reference the project's shared PVC for it.
+			volumeName = source
+			pvc := pvcs[source]
+			volumes = append(volumes, api.Volume{
+				Name: volumeName,
+				VolumeSource: api.VolumeSource{PersistentVolumeClaim: &api.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvc.ObjectMeta.Name,
+				}},
+			})
+		}
+
+		mounts = append(mounts, api.VolumeMount{
+			Name:      volumeName,
+			MountPath: target,
+		})
+	}
+
+	return volumes, mounts
+}
+
+// namedVolumeSource reports whether a parsed volume source names a
+// top-level compose volume that should be backed by a shared
+// PersistentVolumeClaim, as opposed to being absent (ok is false, meaning
+// an anonymous volume) or an absolute/relative bind-mount path.
+// projectVolumeClaims and serviceVolumes must agree on this classification:
+// if they drift apart, serviceVolumes can look up a PVC that
+// projectVolumeClaims never created.
+func namedVolumeSource(source string, ok bool) bool {
+	return ok && !strings.HasPrefix(source, "/") && !strings.HasPrefix(source, ".")
+}
+
+// splitVolumeSpec parses a compose "volumes:" entry of the form
+// SOURCE:TARGET[:MODE] or bare TARGET. ok is false for a bare target,
+// meaning the volume is anonymous.
+func splitVolumeSpec(spec string) (source, target string, ok bool) {
+	parts := strings.Split(spec, ":")
+	switch len(parts) {
+	case 1:
+		return "", parts[0], false
+	default:
+		return parts[0], parts[1], true
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestSplitVolumeSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       string
+		wantSource string
+		wantTarget string
+		wantOK     bool
+	}{
+		{name: "bare target is anonymous", spec: "/data", wantSource: "", wantTarget: "/data", wantOK: false},
+		{name: "source:target", spec: "data:/data", wantSource: "data", wantTarget: "/data", wantOK: true},
+		{name: "source:target:mode keeps only source and target", spec: "data:/data:ro", wantSource: "data", wantTarget: "/data", wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, target, ok := splitVolumeSpec(tt.spec)
+			if source != tt.wantSource || target != tt.wantTarget || ok != tt.wantOK {
+				t.Errorf("splitVolumeSpec(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.spec, source, target, ok, tt.wantSource, tt.wantTarget, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNamedVolumeSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		ok     bool
+		want   bool
+	}{
+		{name: "anonymous volume", source: "", ok: false, want: false},
+		{name: "absolute bind mount", source: "/var/lib/data", ok: true, want: false},
+		{name: "relative bind mount", source: "./data", ok: true, want: false},
+		{name: "named volume", source: "data", ok: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := namedVolumeSource(tt.source, tt.ok); got != tt.want {
+				t.Errorf("namedVolumeSource(%q, %v) = %v, want %v", tt.source, tt.ok, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestServiceVolumesSharesPVCAcrossServices guards against
+// projectVolumeClaims and serviceVolumes classifying a volume source
+// differently: if they ever drift apart, serviceVolumes looks up a PVC
+// that was never built and nil-dereferences it.
+func TestServiceVolumesSharesPVCAcrossServices(t *testing.T) {
+	pvcs := map[string]*api.PersistentVolumeClaim{
+		"shared-data": {ObjectMeta: api.ObjectMeta{Name: "shared-data"}},
+	}
+
+	webVolumes, webMounts := serviceVolumes("web", []string{"shared-data:/data"}, pvcs)
+	workerVolumes, workerMounts := serviceVolumes("worker", []string{"shared-data:/var/data"}, pvcs)
+
+	for _, volumes := range [][]api.Volume{webVolumes, workerVolumes} {
+		if len(volumes) != 1 || volumes[0].VolumeSource.PersistentVolumeClaim == nil {
+			t.Fatalf("volumes = %+v, want one PersistentVolumeClaim volume", volumes)
+		}
+		if claim := volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName; claim != "shared-data" {
+			t.Errorf("ClaimName = %q, want shared-data", claim)
+		}
+	}
+
+	if len(webMounts) != 1 || webMounts[0].MountPath != "/data" {
+		t.Errorf("web mounts = %+v, want one mount at /data", webMounts)
+	}
+	if len(workerMounts) != 1 || workerMounts[0].MountPath != "/var/data" {
+		t.Errorf("worker mounts = %+v, want one mount at /var/data", workerMounts)
+	}
+}
+
+func TestServiceVolumesBindMountAndAnonymous(t *testing.T) {
+	volumes, mounts := serviceVolumes("web", []string{"/host/path:/container/path", "/anonymous"}, nil)
+	if len(volumes) != 2 || len(mounts) != 2 {
+		t.Fatalf("got %d volumes and %d mounts, want 2 and 2", len(volumes), len(mounts))
+	}
+	if volumes[0].VolumeSource.HostPath == nil || volumes[0].VolumeSource.HostPath.Path != "/host/path" {
+		t.Errorf("volumes[0] = %+v, want a HostPath volume for /host/path", volumes[0])
+	}
+	if volumes[1].VolumeSource.EmptyDir == nil {
+		t.Errorf("volumes[1] = %+v, want an EmptyDir volume", volumes[1])
+	}
+}
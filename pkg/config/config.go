@@ -0,0 +1,143 @@
+// Package config manages kompose's own record of Kubernetes API servers,
+// stored at $HOME/.kube/kompose-config as a list of named contexts. It is
+// deliberately separate from a kubeconfig: ProjectKuber falls back to
+// merging $KUBECONFIG only when no kompose context is selected.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Context is one named Kubernetes API server kompose knows how to talk to.
+type Context struct {
+	Name      string `yaml:"name"`
+	Server    string `yaml:"server"`
+	CAFile    string `yaml:"caFile,omitempty"`
+	Token     string `yaml:"token,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Insecure  bool   `yaml:"insecure,omitempty"`
+}
+
+// Config is the on-disk shape of $HOME/.kube/kompose-config.
+type Config struct {
+	Current  string    `yaml:"current,omitempty"`
+	Contexts []Context `yaml:"contexts,omitempty"`
+}
+
+// Path returns $HOME/.kube/kompose-config, expanding the user's home
+// directory via os/user rather than assuming "~" is shell-expanded.
+func Path() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the current user's home directory: %v", err)
+	}
+	return filepath.Join(u.HomeDir, ".kube", "kompose-config"), nil
+}
+
+// Load reads the kompose config file, returning an empty Config if it
+// doesn't exist yet.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// Save atomically writes cfg to $HOME/.kube/kompose-config.
+func Save(cfg *Config) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %v", path, err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".kompose-config-")
+	if err != nil {
+		return fmt.Errorf("failed to create a temp file next to %s: %v", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move the new %s into place: %v", path, err)
+	}
+	return nil
+}
+
+// Get looks up a context by name.
+func (c *Config) Get(name string) (*Context, bool) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i], true
+		}
+	}
+	return nil, false
+}
+
+// Set adds ctx, or overwrites the existing context with the same name.
+func (c *Config) Set(ctx Context) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == ctx.Name {
+			c.Contexts[i] = ctx
+			return
+		}
+	}
+	c.Contexts = append(c.Contexts, ctx)
+}
+
+// Delete removes the named context, clearing Current if it pointed at it.
+// It reports whether a context was actually removed.
+func (c *Config) Delete(name string) bool {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+			if c.Current == name {
+				c.Current = ""
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Use selects name as Current, failing if no such context exists.
+func (c *Config) Use(name string) error {
+	if _, ok := c.Get(name); !ok {
+		return fmt.Errorf("no such context %q", name)
+	}
+	c.Current = name
+	return nil
+}
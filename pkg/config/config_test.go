@@ -0,0 +1,64 @@
+package config
+
+import "testing"
+
+func TestConfigSetGet(t *testing.T) {
+	cfg := &Config{}
+
+	cfg.Set(Context{Name: "dev", Server: "https://dev.example.com"})
+	ctx, ok := cfg.Get("dev")
+	if !ok || ctx.Server != "https://dev.example.com" {
+		t.Fatalf("Get(\"dev\") = %+v, %v, want the dev context", ctx, ok)
+	}
+
+	cfg.Set(Context{Name: "dev", Server: "https://dev2.example.com"})
+	if len(cfg.Contexts) != 1 {
+		t.Fatalf("len(Contexts) = %d after overwriting \"dev\", want 1", len(cfg.Contexts))
+	}
+	ctx, _ = cfg.Get("dev")
+	if ctx.Server != "https://dev2.example.com" {
+		t.Errorf("Get(\"dev\").Server = %q, want the overwritten server", ctx.Server)
+	}
+
+	if _, ok := cfg.Get("missing"); ok {
+		t.Errorf("Get(\"missing\") = true, want false")
+	}
+}
+
+func TestConfigUse(t *testing.T) {
+	cfg := &Config{}
+	cfg.Set(Context{Name: "dev"})
+
+	if err := cfg.Use("missing"); err == nil {
+		t.Error("Use(\"missing\") = nil, want an error")
+	}
+	if err := cfg.Use("dev"); err != nil {
+		t.Fatalf("Use(\"dev\") = %v, want nil", err)
+	}
+	if cfg.Current != "dev" {
+		t.Errorf("Current = %q, want dev", cfg.Current)
+	}
+}
+
+func TestConfigDelete(t *testing.T) {
+	cfg := &Config{Current: "dev"}
+	cfg.Set(Context{Name: "dev"})
+	cfg.Set(Context{Name: "prod"})
+
+	if !cfg.Delete("dev") {
+		t.Fatal("Delete(\"dev\") = false, want true")
+	}
+	if cfg.Current != "" {
+		t.Errorf("Current = %q after deleting it, want cleared", cfg.Current)
+	}
+	if _, ok := cfg.Get("dev"); ok {
+		t.Error("Get(\"dev\") found a context after Delete")
+	}
+	if _, ok := cfg.Get("prod"); !ok {
+		t.Error("Delete(\"dev\") also removed prod")
+	}
+
+	if cfg.Delete("missing") {
+		t.Error("Delete(\"missing\") = true, want false")
+	}
+}
@@ -0,0 +1,94 @@
+// Package kobject holds the intermediate Kubernetes representation a
+// compose project is converted into, before being handed to a
+// transformer.Transformer for a specific output provider.
+package kobject
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// KubernetesObjects collects everything ProjectKuber produced for a compose
+// project, grouped by kind so transformers can handle each kind on its own
+// terms before flattening them with All.
+type KubernetesObjects struct {
+	Deployments []*api.Deployment
+	Services    []*api.Service
+	Ingresses   []*Ingress
+	PVCs        []*api.PersistentVolumeClaim
+}
+
+// Ingress mirrors the stable networking.k8s.io/v1 Ingress, to the extent
+// kompose's conversion needs. k8s.io/kubernetes/pkg/apis/extensions only
+// has the older extensions/v1beta1 shape (ServiceName/ServicePort instead
+// of the nested Service.Name/Service.Port.Number), so it can't be
+// marshaled under a networking.k8s.io/v1 apiVersion.
+type Ingress struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+	Spec           IngressSpec `json:"spec,omitempty"`
+}
+
+// IngressSpec is the Spec of an Ingress.
+type IngressSpec struct {
+	Rules []IngressRule `json:"rules,omitempty"`
+	TLS   []IngressTLS  `json:"tls,omitempty"`
+}
+
+// IngressRule routes a Host to a backend service.
+type IngressRule struct {
+	Host string                `json:"host,omitempty"`
+	HTTP *HTTPIngressRuleValue `json:"http,omitempty"`
+}
+
+// HTTPIngressRuleValue lists the paths routed for a Host.
+type HTTPIngressRuleValue struct {
+	Paths []HTTPIngressPath `json:"paths"`
+}
+
+// HTTPIngressPath routes a path to a backend service.
+type HTTPIngressPath struct {
+	Path    string         `json:"path,omitempty"`
+	Backend IngressBackend `json:"backend"`
+}
+
+// IngressBackend names the Service a path is routed to.
+type IngressBackend struct {
+	Service IngressServiceBackend `json:"service"`
+}
+
+// IngressServiceBackend names a Service and one of its ports.
+type IngressServiceBackend struct {
+	Name string             `json:"name"`
+	Port IngressServicePort `json:"port"`
+}
+
+// IngressServicePort identifies a Service port by number.
+type IngressServicePort struct {
+	Number int `json:"number"`
+}
+
+// IngressTLS requests TLS termination for a set of hosts using a Secret.
+type IngressTLS struct {
+	Hosts      []string `json:"hosts,omitempty"`
+	SecretName string   `json:"secretName,omitempty"`
+}
+
+// All flattens the typed slices into the []runtime.Object shape
+// transformer.Transformer consumes.
+func (k *KubernetesObjects) All() []runtime.Object {
+	var objs []runtime.Object
+	for _, d := range k.Deployments {
+		objs = append(objs, d)
+	}
+	for _, s := range k.Services {
+		objs = append(objs, s)
+	}
+	for _, i := range k.Ingresses {
+		objs = append(objs, i)
+	}
+	for _, p := range k.PVCs {
+		objs = append(objs, p)
+	}
+	return objs
+}
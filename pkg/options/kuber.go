@@ -0,0 +1,23 @@
+// Package options holds the option structs kompose's CLI actions build
+// from a cli.Context, the same role docker/libcompose/project/options
+// plays for the compose actions (options.Up, options.Build, ...).
+package options
+
+// Kuber holds the options for the "kube" action: which compose file to
+// read, where to write the conversion, which provider renders it, and the
+// knobs that affect how compose services are mapped to Kubernetes objects.
+type Kuber struct {
+	ComposeFile string
+	OutputDir   string
+
+	// Provider selects the Transformer: "kubernetes" (default),
+	// "openshift", "helm", or "kustomize". Chart is a shorthand for
+	// Provider == "helm".
+	Provider string
+	Chart    bool
+
+	// StorageClassName names the StorageClass PersistentVolumeClaims
+	// generated from named volumes should request. Empty means the
+	// cluster default.
+	StorageClassName string
+}
@@ -0,0 +1,206 @@
+// Package helm is the Helm Transformer: it scaffolds a chart (Chart.yaml,
+// values.yaml, .helmignore, templates/) that can be handed straight to
+// "helm install" instead of requiring a live cluster at conversion time.
+package helm
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	yamlv2 "gopkg.in/yaml.v2"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/JJediny/kompose/pkg/kobject"
+	"github.com/JJediny/kompose/pkg/transformer"
+)
+
+const chartAPIVersion = "v1"
+
+type chartMeta struct {
+	APIVersion  string `yaml:"apiVersion"`
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+}
+
+type chartResources struct {
+	Limits   map[string]string `yaml:"limits,omitempty"`
+	Requests map[string]string `yaml:"requests,omitempty"`
+}
+
+type chartServicePorts struct {
+	Ports []int `yaml:"ports"`
+}
+
+type chartServiceValues struct {
+	Image        string            `yaml:"image"`
+	ReplicaCount int               `yaml:"replicaCount"`
+	Service      chartServicePorts `yaml:"service"`
+	Resources    chartResources    `yaml:"resources,omitempty"`
+}
+
+// Helm is the "helm" Transformer.
+type Helm struct {
+	// ChartName names the generated Chart.yaml; it's normally the compose
+	// project name.
+	ChartName string
+}
+
+// New returns a Helm Transformer for the named chart.
+func New(chartName string) *Helm {
+	return &Helm{ChartName: chartName}
+}
+
+// Transform renders objs as a chart skeleton: Chart.yaml, values.yaml, one
+// Deployment/Service template pair per service, a literal manifest for
+// each PersistentVolumeClaim and Ingress, .helmignore, and NOTES.txt.
+func (h *Helm) Transform(objs []runtime.Object) ([]transformer.File, error) {
+	deployments := map[string]*api.Deployment{}
+	services := map[string]*api.Service{}
+	pvcs := map[string]*api.PersistentVolumeClaim{}
+	ingresses := map[string]*kobject.Ingress{}
+	for _, obj := range objs {
+		switch t := obj.(type) {
+		case *api.Deployment:
+			deployments[t.ObjectMeta.Name] = t
+		case *api.Service:
+			services[t.ObjectMeta.Name] = t
+		case *api.PersistentVolumeClaim:
+			pvcs[t.ObjectMeta.Name] = t
+		case *kobject.Ingress:
+			ingresses[t.ObjectMeta.Name] = t
+		}
+	}
+
+	var files []transformer.File
+
+	chart, err := yamlv2.Marshal(&chartMeta{
+		APIVersion:  chartAPIVersion,
+		Name:        h.ChartName,
+		Version:     "0.1.0",
+		Description: fmt.Sprintf("A Helm chart generated by kompose for the %s compose project", h.ChartName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Chart.yaml: %v", err)
+	}
+	files = append(files, transformer.File{Name: "Chart.yaml", Data: chart})
+
+	values := map[string]chartServiceValues{}
+	var notes string
+	for name, d := range deployments {
+		container := d.Spec.Template.Spec.Containers[0]
+
+		limits := map[string]string{}
+		if q, ok := container.Resources.Limits[api.ResourceCPU]; ok {
+			limits["cpu"] = q.String()
+		}
+		if q, ok := container.Resources.Limits[api.ResourceMemory]; ok {
+			limits["memory"] = q.String()
+		}
+
+		var ports []int
+		if svc, ok := services[name]; ok {
+			for _, p := range svc.Spec.Ports {
+				ports = append(ports, p.Port)
+			}
+		}
+
+		values[name] = chartServiceValues{
+			Image:        container.Image,
+			ReplicaCount: d.Spec.Replicas,
+			Service:      chartServicePorts{Ports: ports},
+			Resources:    chartResources{Limits: limits},
+		}
+
+		files = append(files, transformer.File{Name: "templates/" + name + "-deployment.yaml", Data: []byte(deploymentTemplate(name))})
+		files = append(files, transformer.File{Name: "templates/" + name + "-service.yaml", Data: []byte(serviceTemplate(name))})
+		notes += fmt.Sprintf("  %s: %s.{{ .Release.Namespace }}.svc.cluster.local\n", name, name)
+	}
+
+	// PVCs and Ingresses aren't parameterized through values.yaml; they're
+	// written as literal manifests alongside the templated Deployments/Services.
+	for name, pvc := range pvcs {
+		data, err := yaml.Marshal(pvc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %v", name, err)
+		}
+		files = append(files, transformer.File{Name: "templates/" + name + "-persistentvolumeclaim.yaml", Data: data})
+	}
+	for name, ingress := range ingresses {
+		data, err := yaml.Marshal(ingress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %v", name, err)
+		}
+		files = append(files, transformer.File{Name: "templates/" + name + "-ingress.yaml", Data: data})
+	}
+
+	valuesYAML, err := yamlv2.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal values.yaml: %v", err)
+	}
+	files = append(files, transformer.File{Name: "values.yaml", Data: valuesYAML})
+	files = append(files, transformer.File{Name: ".helmignore", Data: []byte(helmIgnoreContents)})
+	files = append(files, transformer.File{Name: "templates/NOTES.txt", Data: []byte(notesTemplate(notes))})
+
+	return files, nil
+}
+
+const helmIgnoreContents = `# Patterns to ignore when building packages.
+.git/
+.gitignore
+*.swp
+*.bak
+*.tmp
+*.orig
+`
+
+func deploymentTemplate(name string) string {
+	return fmt.Sprintf(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %[1]s
+  labels:
+    service: %[1]s
+spec:
+  replicas: {{ .Values.%[1]s.replicaCount }}
+  selector:
+    matchLabels:
+      service: %[1]s
+  template:
+    metadata:
+      labels:
+        service: %[1]s
+    spec:
+      containers:
+        - name: %[1]s
+          image: {{ .Values.%[1]s.image }}
+          resources:
+{{ toYaml .Values.%[1]s.resources | indent 12 }}
+`, name)
+}
+
+func serviceTemplate(name string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Service
+metadata:
+  name: %[1]s
+  labels:
+    service: %[1]s
+spec:
+  selector:
+    service: %[1]s
+  ports:
+    {{- range .Values.%[1]s.service.ports }}
+    - port: {{ . }}
+    {{- end }}
+`, name)
+}
+
+func notesTemplate(endpoints string) string {
+	return fmt.Sprintf(`Your services have been deployed. They are reachable within the cluster at:
+
+%s
+Run "kubectl get svc -n {{ .Release.Namespace }}" to see their external status.
+`, endpoints)
+}
@@ -0,0 +1,55 @@
+// Package kubernetes is the raw-k8s Transformer: it writes each object out
+// as its own plain Kubernetes YAML manifest, the behavior ProjectKuber had
+// before the provider abstraction existed.
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/JJediny/kompose/pkg/kobject"
+	"github.com/JJediny/kompose/pkg/transformer"
+)
+
+// Kubernetes is the default Transformer, used when --provider is unset.
+type Kubernetes struct{}
+
+// New returns a Kubernetes Transformer.
+func New() *Kubernetes {
+	return &Kubernetes{}
+}
+
+// Transform renders objs as one YAML manifest per object.
+func (k *Kubernetes) Transform(objs []runtime.Object) ([]transformer.File, error) {
+	var files []transformer.File
+	for _, obj := range objs {
+		name, err := fileName(obj)
+		if err != nil {
+			return nil, err
+		}
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %v", name, err)
+		}
+		files = append(files, transformer.File{Name: name, Data: data})
+	}
+	return files, nil
+}
+
+func fileName(obj runtime.Object) (string, error) {
+	switch o := obj.(type) {
+	case *api.Deployment:
+		return o.ObjectMeta.Name + "-deployment.yaml", nil
+	case *api.Service:
+		return o.ObjectMeta.Name + "-service.yaml", nil
+	case *api.PersistentVolumeClaim:
+		return o.ObjectMeta.Name + "-persistentvolumeclaim.yaml", nil
+	case *kobject.Ingress:
+		return o.ObjectMeta.Name + "-ingress.yaml", nil
+	default:
+		return "", fmt.Errorf("unsupported object type %T", obj)
+	}
+}
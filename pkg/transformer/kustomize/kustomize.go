@@ -0,0 +1,57 @@
+// Package kustomize is the Kustomize Transformer: it writes plain
+// manifests under base/ plus a base/kustomization.yaml listing them as
+// resources, so the output can be layered with environment-specific
+// overlays.
+package kustomize
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/JJediny/kompose/pkg/transformer"
+	rawkube "github.com/JJediny/kompose/pkg/transformer/kubernetes"
+)
+
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// Kustomize is the "kustomize" Transformer.
+type Kustomize struct{}
+
+// New returns a Kustomize Transformer.
+func New() *Kustomize {
+	return &Kustomize{}
+}
+
+// Transform renders objs as one manifest per object under base/, alongside
+// a base/kustomization.yaml listing them all as resources.
+func (k *Kustomize) Transform(objs []runtime.Object) ([]transformer.File, error) {
+	rawFiles, err := rawkube.New().Transform(objs)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []transformer.File
+	var resources []string
+	for _, f := range rawFiles {
+		files = append(files, transformer.File{Name: "base/" + f.Name, Data: f.Data})
+		resources = append(resources, f.Name)
+	}
+
+	data, err := yaml.Marshal(&kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  resources,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kustomization.yaml: %v", err)
+	}
+	files = append(files, transformer.File{Name: "base/kustomization.yaml", Data: data})
+
+	return files, nil
+}
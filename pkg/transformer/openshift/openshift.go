@@ -0,0 +1,137 @@
+// Package openshift is the OpenShift Transformer: Deployments become
+// DeploymentConfigs, and exposed Services get a Route so they're reachable
+// without a NodePort or LoadBalancer.
+package openshift
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/JJediny/kompose/pkg/kobject"
+	"github.com/JJediny/kompose/pkg/transformer"
+)
+
+// DeploymentConfig mirrors apps.openshift.io/v1 DeploymentConfig, to the
+// extent kompose's conversion needs.
+type DeploymentConfig struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+	Spec           DeploymentConfigSpec `json:"spec,omitempty"`
+}
+
+// DeploymentConfigSpec is the Spec of a DeploymentConfig.
+type DeploymentConfigSpec struct {
+	Replicas int                  `json:"replicas"`
+	Selector map[string]string    `json:"selector,omitempty"`
+	Template *api.PodTemplateSpec `json:"template,omitempty"`
+}
+
+// Route mirrors route.openshift.io/v1 Route.
+type Route struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+	Spec           RouteSpec `json:"spec,omitempty"`
+}
+
+// RouteSpec is the Spec of a Route.
+type RouteSpec struct {
+	Host string         `json:"host,omitempty"`
+	To   RouteTargetRef `json:"to"`
+	Port *RoutePort     `json:"port,omitempty"`
+}
+
+// RouteTargetRef names the Service a Route forwards to.
+type RouteTargetRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// RoutePort pins a Route to a single named Service port.
+type RoutePort struct {
+	TargetPort string `json:"targetPort"`
+}
+
+// OpenShift is the "openshift" Transformer.
+type OpenShift struct{}
+
+// New returns an OpenShift Transformer.
+func New() *OpenShift {
+	return &OpenShift{}
+}
+
+// Transform renders objs as OpenShift manifests, one file per object (plus
+// one Route per exposed Service).
+func (o *OpenShift) Transform(objs []runtime.Object) ([]transformer.File, error) {
+	var files []transformer.File
+	for _, obj := range objs {
+		switch t := obj.(type) {
+		case *api.Deployment:
+			dc := deploymentConfigFrom(t)
+			data, err := yaml.Marshal(dc)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal %s: %v", dc.ObjectMeta.Name, err)
+			}
+			files = append(files, transformer.File{Name: dc.ObjectMeta.Name + "-deploymentconfig.yaml", Data: data})
+		case *api.Service:
+			data, err := yaml.Marshal(t)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal %s: %v", t.ObjectMeta.Name, err)
+			}
+			files = append(files, transformer.File{Name: t.ObjectMeta.Name + "-service.yaml", Data: data})
+
+			if route := routeFrom(t); route != nil {
+				routeData, err := yaml.Marshal(route)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal %s: %v", route.ObjectMeta.Name, err)
+				}
+				files = append(files, transformer.File{Name: route.ObjectMeta.Name + "-route.yaml", Data: routeData})
+			}
+		case *api.PersistentVolumeClaim:
+			data, err := yaml.Marshal(t)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal %s: %v", t.ObjectMeta.Name, err)
+			}
+			files = append(files, transformer.File{Name: t.ObjectMeta.Name + "-persistentvolumeclaim.yaml", Data: data})
+		case *kobject.Ingress:
+			data, err := yaml.Marshal(t)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal %s: %v", t.ObjectMeta.Name, err)
+			}
+			files = append(files, transformer.File{Name: t.ObjectMeta.Name + "-ingress.yaml", Data: data})
+		default:
+			return nil, fmt.Errorf("unsupported object type %T", obj)
+		}
+	}
+	return files, nil
+}
+
+func deploymentConfigFrom(d *api.Deployment) *DeploymentConfig {
+	return &DeploymentConfig{
+		TypeMeta:   api.TypeMeta{Kind: "DeploymentConfig", APIVersion: "apps.openshift.io/v1"},
+		ObjectMeta: d.ObjectMeta,
+		Spec: DeploymentConfigSpec{
+			Replicas: d.Spec.Replicas,
+			Selector: d.Spec.Selector,
+			Template: d.Spec.Template,
+		},
+	}
+}
+
+// routeFrom exposes a Service's first port via a Route. Services with no
+// ports have nothing to route to.
+func routeFrom(s *api.Service) *Route {
+	if len(s.Spec.Ports) == 0 {
+		return nil
+	}
+	return &Route{
+		TypeMeta:   api.TypeMeta{Kind: "Route", APIVersion: "route.openshift.io/v1"},
+		ObjectMeta: api.ObjectMeta{Name: s.ObjectMeta.Name, Labels: s.ObjectMeta.Labels},
+		Spec: RouteSpec{
+			To:   RouteTargetRef{Kind: "Service", Name: s.ObjectMeta.Name},
+			Port: &RoutePort{TargetPort: fmt.Sprintf("%d", s.Spec.Ports[0].Port)},
+		},
+	}
+}
@@ -0,0 +1,21 @@
+// Package transformer defines the pluggable emitter interface ProjectKuber
+// dispatches to once it has converted a compose project into Kubernetes
+// objects. Each output provider (kubernetes, openshift, helm, kustomize)
+// ships its own implementation under a transformer/<provider> subpackage.
+package transformer
+
+import "k8s.io/kubernetes/pkg/runtime"
+
+// File is a single rendered file a Transformer wants written under the
+// conversion's output directory. Name may include subdirectories, e.g.
+// "base/kustomization.yaml".
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Transformer renders a set of Kubernetes objects into the files for one
+// output provider.
+type Transformer interface {
+	Transform(objs []runtime.Object) ([]File, error)
+}